@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ARMeeru/jupiter/output"
+	"github.com/ARMeeru/jupiter/proto/weatherdpb"
+)
+
+// cityList accumulates repeated --city flags into a slice.
+type cityList []string
+
+func (c *cityList) String() string { return "" }
+func (c *cityList) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
+func defaultConcurrency() int {
+	if n := runtime.NumCPU(); n < 8 {
+		return n
+	}
+	return 8
+}
+
+// clampConcurrency floors --concurrency at 1: 0 would deadlock the worker
+// pool (the first slot never gets drained) and a negative value panics
+// make(chan struct{}, n).
+func clampConcurrency(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+func runBatch(logger *log.Logger, args []string) {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:50051", "weatherd address")
+	format := fs.String("format", "text", "output format: text, json, yaml, table, prometheus")
+	citiesFile := fs.String("cities-file", "", "YAML file containing a list of city names")
+	concurrency := fs.Int("concurrency", defaultConcurrency(), "number of cities to fetch at once")
+	units := fs.String("units", "", "metric, imperial, or standard (default: weatherd's configured default)")
+	refresh := fs.Bool("refresh", false, "bypass weatherd's cache and revalidate against the network")
+	offline := fs.Bool("offline", false, "serve only from weatherd's cache; fail instead of hitting the network")
+	var cities cityList
+	fs.Var(&cities, "city", "city to fetch; repeat for multiple")
+	fs.Parse(args)
+
+	reqUnits, err := parseUnits(*units)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if *citiesFile != "" {
+		fromFile, err := loadCitiesFile(*citiesFile)
+		if err != nil {
+			logger.Println(err)
+			fmt.Println(err)
+			return
+		}
+		cities = append(cities, fromFile...)
+	}
+	cities = append(cities, fs.Args()...)
+
+	if len(cities) == 0 {
+		fmt.Println("Please provide at least one location via --city, --cities-file, or a positional argument.")
+		return
+	}
+
+	conn, err := dial(*addr, logger)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	renderer, err := output.New(*format)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	client := weatherdpb.NewWeatherServiceClient(conn)
+	results := fetchBatch(client, cities, clampConcurrency(*concurrency), reqUnits, *refresh, *offline)
+
+	report := output.Report{Batch: results}
+	if err := renderer.Render(os.Stdout, report); err != nil {
+		logger.Println("Error rendering report:", err)
+		fmt.Println(err)
+	}
+}
+
+func loadCitiesFile(path string) ([]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cities file: %v", err)
+	}
+	var cities []string
+	if err := yaml.Unmarshal(data, &cities); err != nil {
+		return nil, fmt.Errorf("error parsing cities file: %v", err)
+	}
+	return cities, nil
+}
+
+// fetchBatch runs a worker pool of `concurrency` goroutines over cities,
+// preserving the original order in the returned slice.
+func fetchBatch(client weatherdpb.WeatherServiceClient, cities []string, concurrency int, units weatherdpb.Units, refresh, offline bool) []output.BatchResult {
+	results := make([]output.BatchResult, len(cities))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, city := range cities {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, city string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = fetchOne(client, city, units, refresh, offline)
+		}(i, city)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func fetchOne(client weatherdpb.WeatherServiceClient, city string, units weatherdpb.Units, refresh, offline bool) output.BatchResult {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	loc := &weatherdpb.Location{Query: &weatherdpb.Location_City{City: city}}
+	weatherData, err := client.GetCurrent(ctx, &weatherdpb.RequestCurrent{Location: loc, Units: units, Refresh: refresh, Offline: offline})
+	if err != nil {
+		return output.BatchResult{Query: city, Err: friendlyError(err)}
+	}
+
+	return output.BatchResult{
+		Query:    city,
+		Location: weatherData.Name,
+		Country:  weatherData.Country,
+		Warning:  weatherData.Warning,
+		Current: &output.CurrentData{
+			Temp:        weatherData.Temp,
+			FeelsLike:   weatherData.FeelsLike,
+			Pressure:    weatherData.Pressure,
+			Humidity:    weatherData.Humidity,
+			Condition:   weatherData.Condition,
+			Description: weatherData.Description,
+		},
+	}
+}
@@ -0,0 +1,88 @@
+// Package cache stores raw API responses on disk under
+// $XDG_CACHE_HOME/jupiter (falling back to os.UserCacheDir), so that
+// weatherd doesn't have to re-hit OpenWeatherMap for data it already has
+// within the caller's TTL, and can still serve --offline requests from
+// whatever it last saw.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// NoExpiry, passed as the ttl to Get, means the entry never goes stale
+// (used for geocoding results, which don't change).
+const NoExpiry time.Duration = 0
+
+type entry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// Cache is a directory of JSON-wrapped entries, one file per key.
+type Cache struct {
+	dir string
+}
+
+// New resolves the cache directory (creating it if needed) and returns a
+// Cache rooted there.
+func New() (*Cache, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("error resolving cache directory: %v", err)
+		}
+		base = userCache
+	}
+
+	dir := filepath.Join(base, "jupiter")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %v", err)
+	}
+
+	return &Cache{dir: dir}, nil
+}
+
+// Get returns the cached body for key if present and, unless ttl is
+// NoExpiry, not older than ttl.
+func (c *Cache) Get(key string, ttl time.Duration) (json.RawMessage, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+
+	if ttl != NoExpiry && time.Since(e.FetchedAt) > ttl {
+		return nil, false
+	}
+
+	return e.Body, true
+}
+
+// Set stores body under key, stamped with the current time.
+func (c *Cache) Set(key string, body json.RawMessage) error {
+	data, err := json.Marshal(entry{FetchedAt: time.Now(), Body: body})
+	if err != nil {
+		return fmt.Errorf("error marshalling cache entry: %v", err)
+	}
+	return ioutil.WriteFile(c.path(key), data, 0644)
+}
+
+// path maps a cache key to a filename; keys are hashed so that arbitrary
+// characters (e.g. a city query with commas and spaces) are always
+// filesystem-safe.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestCache(t *testing.T) *Cache {
+	t.Helper()
+	return &Cache{dir: t.TempDir()}
+}
+
+func TestCacheSetGetRoundTrip(t *testing.T) {
+	c := newTestCache(t)
+
+	body := json.RawMessage(`{"temp":21.5}`)
+	if err := c.Set("current|1|2", body); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, ok := c.Get("current|1|2", time.Hour)
+	if !ok {
+		t.Fatal("Get: expected a hit")
+	}
+	if string(got) != string(body) {
+		t.Errorf("Get returned %s, want %s", got, body)
+	}
+}
+
+func TestCacheGetMiss(t *testing.T) {
+	c := newTestCache(t)
+
+	if _, ok := c.Get("never-set", time.Hour); ok {
+		t.Fatal("Get: expected a miss for a key that was never Set")
+	}
+}
+
+func TestCacheGetExpiresAfterTTL(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.Set("stale", json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// Backdate the entry on disk so it reads as older than the TTL.
+	data, err := ioutil.ReadFile(c.path("stale"))
+	if err != nil {
+		t.Fatalf("reading entry: %v", err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("unmarshalling entry: %v", err)
+	}
+	e.FetchedAt = time.Now().Add(-time.Hour)
+	backdated, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("marshalling backdated entry: %v", err)
+	}
+	if err := ioutil.WriteFile(c.path("stale"), backdated, 0644); err != nil {
+		t.Fatalf("writing backdated entry: %v", err)
+	}
+
+	if _, ok := c.Get("stale", time.Minute); ok {
+		t.Fatal("Get: expected a miss once the entry is older than ttl")
+	}
+}
+
+func TestCacheGetNoExpiryIgnoresAge(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.Set("geo|london", json.RawMessage(`{"lat":51.5}`)); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(c.path("geo|london"))
+	if err != nil {
+		t.Fatalf("reading entry: %v", err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("unmarshalling entry: %v", err)
+	}
+	e.FetchedAt = time.Now().Add(-24 * time.Hour)
+	backdated, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("marshalling backdated entry: %v", err)
+	}
+	if err := ioutil.WriteFile(c.path("geo|london"), backdated, 0644); err != nil {
+		t.Fatalf("writing backdated entry: %v", err)
+	}
+
+	if _, ok := c.Get("geo|london", NoExpiry); !ok {
+		t.Fatal("Get: expected NoExpiry to ignore entry age")
+	}
+}
+
+func TestCachePathIsFilesystemSafe(t *testing.T) {
+	c := newTestCache(t)
+
+	p := c.path("current|51.5074|-0.1278|metric|")
+	if filepath.Dir(p) != c.dir {
+		t.Errorf("path() escaped the cache directory: %s", p)
+	}
+	if filepath.Base(p) == "current|51.5074|-0.1278|metric|" {
+		t.Error("path() did not hash the key")
+	}
+}
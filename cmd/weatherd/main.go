@@ -0,0 +1,220 @@
+// Command weatherd is the gRPC daemon that holds the OpenWeatherMap API key
+// and serves current and five-day forecast weather to any number of clients
+// (the jupiter CLI among them), so the key only ever lives in one process.
+// GetHistorical is registered but answers every call with Unimplemented.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ARMeeru/jupiter/cache"
+	"github.com/ARMeeru/jupiter/config"
+	"github.com/ARMeeru/jupiter/geo"
+	"github.com/ARMeeru/jupiter/proto/weatherdpb"
+	"github.com/ARMeeru/jupiter/weather"
+)
+
+type server struct {
+	weatherdpb.UnimplementedWeatherServiceServer
+
+	client *weather.Client
+}
+
+// resolve turns a Location query into a geo.Location plus a note describing
+// any ambiguity the geocoder resolved on the caller's behalf (e.g. multiple
+// candidates matched a city name) — "" if there was none. The note travels
+// back to the CLI on the response's Warning field, since weatherd's own
+// stdout/log never reaches whoever actually typed the query.
+func (s *server) resolve(ctx context.Context, loc *weatherdpb.Location, opts weather.CacheOptions) (geo.Location, string, error) {
+	if loc == nil {
+		return geo.Location{}, "", status.Error(codes.InvalidArgument, "location is required")
+	}
+
+	switch q := loc.GetQuery().(type) {
+	case *weatherdpb.Location_City:
+		if q.City == "" {
+			return geo.Location{}, "", status.Error(codes.InvalidArgument, "city must not be empty")
+		}
+		resolved, note, err := s.client.ResolveLocation(ctx, q.City, opts)
+		if err != nil {
+			if errors.Is(err, geo.ErrNotFound) {
+				return geo.Location{}, "", status.Error(codes.NotFound, err.Error())
+			}
+			return geo.Location{}, "", status.Errorf(codes.Unavailable, "geocoding failed: %v", err)
+		}
+		return resolved, note, nil
+
+	case *weatherdpb.Location_ZipCode:
+		if q.ZipCode == "" {
+			return geo.Location{}, "", status.Error(codes.InvalidArgument, "zip_code must not be empty")
+		}
+		resolved, note, err := s.client.ResolveLocation(ctx, q.ZipCode, opts)
+		if err != nil {
+			if errors.Is(err, geo.ErrNotFound) {
+				return geo.Location{}, "", status.Error(codes.NotFound, err.Error())
+			}
+			return geo.Location{}, "", status.Errorf(codes.Unavailable, "geocoding failed: %v", err)
+		}
+		return resolved, note, nil
+
+	case *weatherdpb.Location_Coordinates:
+		if q.Coordinates == nil {
+			return geo.Location{}, "", status.Error(codes.InvalidArgument, "coordinates must not be empty")
+		}
+		return geo.ResolveCoordinates(q.Coordinates.GetLat(), q.Coordinates.GetLon()), "", nil
+
+	default:
+		return geo.Location{}, "", status.Error(codes.InvalidArgument, "location must set city, zip_code, or coordinates")
+	}
+}
+
+func (s *server) GetCurrent(ctx context.Context, req *weatherdpb.RequestCurrent) (*weatherdpb.SendWeather, error) {
+	opts := weather.CacheOptions{Refresh: req.GetRefresh(), Offline: req.GetOffline()}
+
+	loc, note, err := s.resolve(ctx, req.GetLocation(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.client.Current(ctx, loc, unitsToString(req.GetUnits()), "", opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "upstream weather API failed: %v", err)
+	}
+
+	out := toSendWeather(data, loc)
+	out.Warning = note
+	return out, nil
+}
+
+func (s *server) GetFiveDay(ctx context.Context, req *weatherdpb.RequestFiveDay) (*weatherdpb.SendForecast, error) {
+	opts := weather.CacheOptions{Refresh: req.GetRefresh(), Offline: req.GetOffline()}
+
+	loc, note, err := s.resolve(ctx, req.GetLocation(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := s.client.FiveDay(ctx, loc, unitsToString(req.GetUnits()), "", opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "upstream forecast API failed: %v", err)
+	}
+
+	out := toSendForecast(forecast)
+	out.Warning = note
+	return out, nil
+}
+
+// unitsToString maps the proto Units enum to the query-param value
+// OpenWeatherMap expects. UNITS_UNSPECIFIED maps to "", which tells
+// weather.Client to fall back to its configured default (JUPITER_UNITS, or
+// "metric" absent that) rather than to any one unit system.
+func unitsToString(u weatherdpb.Units) string {
+	switch u {
+	case weatherdpb.Units_METRIC:
+		return "metric"
+	case weatherdpb.Units_IMPERIAL:
+		return "imperial"
+	case weatherdpb.Units_STANDARD:
+		return "standard"
+	default:
+		return ""
+	}
+}
+
+func (s *server) GetHistorical(ctx context.Context, req *weatherdpb.RequestHistorical) (*weatherdpb.SendWeather, error) {
+	return nil, status.Error(codes.Unimplemented, "historical weather is not implemented yet")
+}
+
+func toSendWeather(data *weather.WeatherData, loc geo.Location) *weatherdpb.SendWeather {
+	out := &weatherdpb.SendWeather{
+		Name:      data.Name,
+		Country:   loc.Country,
+		Temp:      data.Main.Temp,
+		FeelsLike: data.Main.FeelsLike,
+		Pressure:  data.Main.Pressure,
+		Humidity:  data.Main.Humidity,
+	}
+	if len(data.Weather) > 0 {
+		out.Condition = data.Weather[0].Main
+		out.Description = data.Weather[0].Description
+	}
+	return out
+}
+
+func toSendForecast(f *weather.Forecast) *weatherdpb.SendForecast {
+	out := &weatherdpb.SendForecast{
+		Name:    f.City.Name,
+		Country: f.City.Country,
+		Sunrise: f.City.Sunrise,
+		Sunset:  f.City.Sunset,
+		Periods: make([]*weatherdpb.ForecastPeriod, len(f.List)),
+	}
+	for i, entry := range f.List {
+		period := &weatherdpb.ForecastPeriod{
+			Dt:         entry.Dt,
+			Temp:       entry.Main.Temp,
+			TempMin:    entry.Main.TempMin,
+			TempMax:    entry.Main.TempMax,
+			WindSpeed:  entry.Wind.Speed,
+			WindDeg:    entry.Wind.Deg,
+			WindGust:   entry.Wind.Gust,
+			Clouds:     entry.Clouds.All,
+			Rain1H:     entry.Rain.OneHour,
+			Rain3H:     entry.Rain.ThreeHour,
+			Snow1H:     entry.Snow.OneHour,
+			Snow3H:     entry.Snow.ThreeHour,
+			Visibility: entry.Visibility,
+			Pop:        entry.Pop,
+		}
+		if len(entry.Weather) > 0 {
+			period.Condition = entry.Weather[0].Main
+			period.Description = entry.Weather[0].Description
+		}
+		out.Periods[i] = period
+	}
+	return out
+}
+
+func main() {
+	addr := flag.String("addr", ":50051", "address to listen on")
+	configPath := flag.String("config", "", "config file path (default: $XDG_CONFIG_HOME/jupiter/config.yaml, then /etc/jupiter/config.yaml)")
+	flag.Parse()
+
+	settings, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("error loading configuration: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("failed to listen on %s: %v", *addr, err)
+	}
+
+	client := weather.NewClient(settings.APIKey)
+	if settings.Units != "" {
+		client.DefaultUnits = settings.Units
+	}
+	client.DefaultLang = settings.Lang
+	if diskCache, err := cache.New(); err != nil {
+		log.Printf("warning: disk cache unavailable, running uncached: %v", err)
+	} else {
+		client.Cache = diskCache
+	}
+
+	grpcServer := grpc.NewServer()
+	weatherdpb.RegisterWeatherServiceServer(grpcServer, &server{client: client})
+
+	fmt.Printf("weatherd listening on %s\n", *addr)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("gRPC server failed: %v", err)
+	}
+}
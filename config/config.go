@@ -0,0 +1,93 @@
+// Package config resolves weatherd's configuration in the order operators
+// expect: an explicit --config flag, then $XDG_CONFIG_HOME/jupiter, then
+// /etc/jupiter, then JUPITER_-prefixed environment variables. The API key
+// itself is handed off to the secrets package so it never has to sit in a
+// world-readable YAML file.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+
+	"github.com/ARMeeru/jupiter/secrets"
+)
+
+// Settings is weatherd's fully-resolved configuration.
+type Settings struct {
+	APIKey string
+	Units  string
+	Lang   string
+}
+
+// Load resolves Settings from one config file (configPath, else the first of
+// $XDG_CONFIG_HOME/jupiter/config.yaml or /etc/jupiter/config.yaml that
+// exists) and JUPITER_-prefixed environment variables; an environment
+// variable that is set overrides the file's value for that key. configPath
+// is the --config flag value; pass "" to skip straight to the XDG/system
+// search.
+func Load(configPath string) (*Settings, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetEnvPrefix("jupiter")
+	v.AutomaticEnv()
+
+	path, err := resolvePath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading config file %s: %v", path, err)
+		}
+	}
+
+	apiKey, err := secrets.Resolve(v.GetString("api_key"), v.GetString("secrets_backend"))
+	if err != nil {
+		return nil, fmt.Errorf("error resolving API key: %w", err)
+	}
+
+	return &Settings{
+		APIKey: apiKey,
+		Units:  v.GetString("units"),
+		Lang:   v.GetString("lang"),
+	}, nil
+}
+
+// resolvePath returns the config file to read, or "" if none of the
+// candidate locations exist (environment variables still apply in that
+// case). explicit, if non-empty, must exist or resolvePath fails loudly
+// rather than silently falling back.
+func resolvePath(explicit string) (string, error) {
+	if explicit != "" {
+		if _, err := os.Stat(explicit); err != nil {
+			return "", fmt.Errorf("config file %s: %v", explicit, err)
+		}
+		return explicit, nil
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		if p := filepath.Join(xdg, "jupiter", "config.yaml"); exists(p) {
+			return p, nil
+		}
+	} else if home, err := os.UserHomeDir(); err == nil {
+		if p := filepath.Join(home, ".config", "jupiter", "config.yaml"); exists(p) {
+			return p, nil
+		}
+	}
+
+	const systemPath = "/etc/jupiter/config.yaml"
+	if exists(systemPath) {
+		return systemPath, nil
+	}
+
+	return "", nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ARMeeru/jupiter/output"
+	"github.com/ARMeeru/jupiter/proto/weatherdpb"
+)
+
+// dayBucket aggregates a SendForecast's periods that fall on the same UTC
+// calendar day, for the compact per-day table.
+type dayBucket struct {
+	day        string
+	tempMin    float64
+	tempMax    float64
+	precipMM   float64
+	conditions map[string]int
+}
+
+func runForecast(logger *log.Logger, args []string) {
+	fs := flag.NewFlagSet("forecast", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:50051", "weatherd address")
+	lat := fs.Float64("lat", 0, "latitude (use with --lon to skip geocoding)")
+	lon := fs.Float64("lon", 0, "longitude (use with --lat to skip geocoding)")
+	format := fs.String("format", "text", "output format: text, json, yaml, table")
+	hourly := fs.Bool("hourly", false, "print the full 3-hour breakdown instead of a per-day summary")
+	units := fs.String("units", "", "metric, imperial, or standard (default: weatherd's configured default)")
+	refresh := fs.Bool("refresh", false, "bypass weatherd's cache and revalidate against the network")
+	offline := fs.Bool("offline", false, "serve only from weatherd's cache; fail instead of hitting the network")
+	fs.Parse(args)
+
+	loc, err := buildLocation(*lat, *lon, fs.Args())
+	if err != nil {
+		logger.Println(err)
+		fmt.Println(err)
+		return
+	}
+
+	reqUnits, err := parseUnits(*units)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	conn, err := dial(*addr, logger)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	renderer, err := output.New(*format)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	client := weatherdpb.NewWeatherServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	forecast, err := client.GetFiveDay(ctx, &weatherdpb.RequestFiveDay{Location: loc, Units: reqUnits, Refresh: *refresh, Offline: *offline})
+	if err != nil {
+		logger.Println("Error getting forecast data:", err)
+		fmt.Println(friendlyError(err))
+		return
+	}
+
+	report := output.Report{
+		Location: forecast.Name,
+		Country:  forecast.Country,
+		Sunrise:  forecast.Sunrise,
+		Sunset:   forecast.Sunset,
+		Warning:  forecast.Warning,
+	}
+	if *hourly {
+		report.Hourly = toHourly(forecast.Periods)
+	} else {
+		report.Daily = toDaily(forecast.Periods)
+	}
+
+	if err := renderer.Render(os.Stdout, report); err != nil {
+		logger.Println("Error rendering report:", err)
+		fmt.Println(err)
+	}
+}
+
+func toHourly(periods []*weatherdpb.ForecastPeriod) []output.ForecastPeriod {
+	out := make([]output.ForecastPeriod, len(periods))
+	for i, p := range periods {
+		out[i] = output.ForecastPeriod{
+			Time:        time.Unix(p.Dt, 0).UTC().Format("2006-01-02 15:04"),
+			Temp:        p.Temp,
+			Condition:   p.Condition,
+			Description: p.Description,
+			WindSpeed:   p.WindSpeed,
+			WindDeg:     p.WindDeg,
+			WindGust:    p.WindGust,
+			Clouds:      p.Clouds,
+			Visibility:  p.Visibility,
+			PrecipMM:    p.Rain3H + p.Snow3H,
+			Pop:         p.Pop,
+		}
+	}
+	return out
+}
+
+func toDaily(periods []*weatherdpb.ForecastPeriod) []output.ForecastDay {
+	buckets := bucketByDay(periods)
+	days := make([]output.ForecastDay, len(buckets))
+	for i, b := range buckets {
+		days[i] = output.ForecastDay{
+			Day:       b.day,
+			TempMin:   b.tempMin,
+			TempMax:   b.tempMax,
+			Condition: dominantCondition(b.conditions),
+			PrecipMM:  b.precipMM,
+		}
+	}
+	return days
+}
+
+func bucketByDay(periods []*weatherdpb.ForecastPeriod) []*dayBucket {
+	index := make(map[string]*dayBucket)
+	var order []string
+
+	for _, p := range periods {
+		day := time.Unix(p.Dt, 0).UTC().Format("2006-01-02")
+		b, ok := index[day]
+		if !ok {
+			b = &dayBucket{day: day, tempMin: p.TempMin, tempMax: p.TempMax, conditions: make(map[string]int)}
+			index[day] = b
+			order = append(order, day)
+		}
+		if p.TempMin < b.tempMin {
+			b.tempMin = p.TempMin
+		}
+		if p.TempMax > b.tempMax {
+			b.tempMax = p.TempMax
+		}
+		b.precipMM += p.Rain3H + p.Snow3H
+		b.conditions[p.Condition]++
+	}
+
+	buckets := make([]*dayBucket, len(order))
+	for i, day := range order {
+		buckets[i] = index[day]
+	}
+	return buckets
+}
+
+func dominantCondition(counts map[string]int) string {
+	best, bestCount := "", 0
+	// Sort keys for deterministic output when counts tie.
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if counts[k] > bestCount {
+			best, bestCount = k, counts[k]
+		}
+	}
+	return best
+}
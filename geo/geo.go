@@ -0,0 +1,222 @@
+// Package geo resolves free-form location input (city names, "City, State,
+// Country", ZIP/postal codes, or raw coordinates) to the canonical
+// latitude/longitude pairs that the OpenWeatherMap weather endpoints expect.
+//
+// Resolving through the geocoding API first avoids the old ambiguous
+// City-name lookups, where a query like "Los Angeles" could silently match
+// the wrong place (e.g. Port Angeles) depending on how OpenWeatherMap's
+// /weather endpoint interpreted the q= parameter.
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned (wrapped, so errors.Is still works) when a query
+// matches zero geocoding candidates. Callers that need to distinguish "no
+// such place" from other failures (e.g. to map it to a gRPC status code)
+// should check against it with errors.Is.
+var ErrNotFound = errors.New("no location found")
+
+const (
+	directURL = "https://api.openweathermap.org/geo/1.0/direct"
+	zipURL    = "https://api.openweathermap.org/geo/1.0/zip"
+)
+
+// zipPattern matches "12345,US" / "90001,US" style postal code queries. The
+// code part must contain a digit, so "Paris, FR" stays a city query.
+var zipPattern = regexp.MustCompile(`^\s*[\w-]*\d[\w-]*\s*,\s*[A-Za-z]{2}\s*$`)
+
+// Location is the canonical, disambiguated place a query resolves to.
+type Location struct {
+	Name    string  `json:"name"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Country string  `json:"country"`
+	State   string  `json:"state,omitempty"`
+}
+
+// directResult mirrors one entry of the /geo/1.0/direct response.
+type directResult struct {
+	Name    string  `json:"name"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Country string  `json:"country"`
+	State   string  `json:"state"`
+}
+
+// zipResult mirrors the /geo/1.0/zip response, which always returns a
+// single, already-disambiguated location.
+type zipResult struct {
+	Name    string  `json:"name"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Country string  `json:"country"`
+}
+
+// Geocoder resolves queries to Locations, caching results by normalized
+// query so that repeat runs (e.g. a script polling the same city) don't
+// burn extra API calls. A single Geocoder is shared across every
+// concurrent weatherd request (see weather.Client), so the cache is
+// mutex-guarded.
+type Geocoder struct {
+	APIKey string
+	Client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]Location
+}
+
+// NewGeocoder builds a Geocoder ready for use.
+func NewGeocoder(apiKey string) *Geocoder {
+	return &Geocoder{
+		APIKey: apiKey,
+		Client: &http.Client{},
+		cache:  make(map[string]Location),
+	}
+}
+
+// Resolve turns a free-form query into a canonical Location. query may be a
+// city name, "City, Country", "City, State, Country", or a ZIP/postal code
+// in "12345,US" form. ctx governs the underlying geocoding API call, so a
+// caller that gives up (e.g. a gRPC client that hit its deadline) stops the
+// request instead of letting it run to completion unread.
+// Resolve returns the resolved Location and, if query matched more than one
+// candidate, a human-readable note listing the alternatives that were
+// passed over so a caller can surface it to the user instead of silently
+// picking one. note is always "" on a cache hit.
+func (g *Geocoder) Resolve(ctx context.Context, query string) (Location, string, error) {
+	key := normalize(query)
+
+	g.mu.Lock()
+	loc, ok := g.cache[key]
+	g.mu.Unlock()
+	if ok {
+		return loc, "", nil
+	}
+
+	var note string
+	var err error
+	if zipPattern.MatchString(query) {
+		loc, err = g.lookupZip(ctx, query)
+	} else {
+		loc, note, err = g.lookupDirect(ctx, query)
+	}
+	if err != nil {
+		return Location{}, "", err
+	}
+
+	g.mu.Lock()
+	g.cache[key] = loc
+	g.mu.Unlock()
+	return loc, note, nil
+}
+
+// ResolveCoordinates builds a Location directly from a lat/lon pair,
+// bypassing geocoding entirely (OpenWeatherMap still needs a name for
+// display, so it's left blank and filled in by the caller if known).
+func ResolveCoordinates(lat, lon float64) Location {
+	return Location{Lat: lat, Lon: lon}
+}
+
+func (g *Geocoder) lookupDirect(ctx context.Context, query string) (Location, string, error) {
+	u := fmt.Sprintf("%s?q=%s&limit=5&appid=%s", directURL, url.QueryEscape(query), g.APIKey)
+	var results []directResult
+	if err := g.get(ctx, u, &results); err != nil {
+		return Location{}, "", err
+	}
+	if len(results) == 0 {
+		return Location{}, "", fmt.Errorf("%w: %q", ErrNotFound, query)
+	}
+
+	candidates := make([]Location, len(results))
+	for i, r := range results {
+		candidates[i] = Location{Name: r.Name, Lat: r.Lat, Lon: r.Lon, Country: r.Country, State: r.State}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], "", nil
+	}
+	loc, note := g.disambiguate(query, candidates)
+	return loc, note, nil
+}
+
+func (g *Geocoder) lookupZip(ctx context.Context, query string) (Location, error) {
+	u := fmt.Sprintf("%s?zip=%s&appid=%s", zipURL, url.QueryEscape(strings.ReplaceAll(query, " ", "")), g.APIKey)
+	var result zipResult
+	if err := g.get(ctx, u, &result); err != nil {
+		return Location{}, err
+	}
+	if result.Name == "" {
+		return Location{}, fmt.Errorf("%w: ZIP %q", ErrNotFound, query)
+	}
+	return Location{Name: result.Name, Lat: result.Lat, Lon: result.Lon, Country: result.Country}, nil
+}
+
+// disambiguate auto-selects the first of several candidates and returns a
+// note describing the rest so the choice isn't silent. Geocoder always runs
+// inside weatherd now (the CLI is a thin gRPC client with no terminal of its
+// own to prompt on, and the gRPC API has no round trip for "here are N
+// candidates, pick one"), so there's no interactive path here; the note
+// travels back over the RPC response instead (see SendWeather/SendForecast's
+// Warning field) so the CLI can still show the user what was passed over.
+// Callers that need a different candidate should narrow the query or pass
+// --lat/--lon.
+func (g *Geocoder) disambiguate(query string, candidates []Location) (Location, string) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "multiple matches for %q: ", query)
+	for i, c := range candidates {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "%d) %s", i+1, describe(c))
+	}
+	fmt.Fprintf(&b, " — using 1) %s (pass --lat/--lon or narrow the query to pick another)", describe(candidates[0]))
+	return candidates[0], b.String()
+}
+
+func describe(l Location) string {
+	if l.State != "" {
+		return fmt.Sprintf("%s, %s, %s (%.4f, %.4f)", l.Name, l.State, l.Country, l.Lat, l.Lon)
+	}
+	return fmt.Sprintf("%s, %s (%.4f, %.4f)", l.Name, l.Country, l.Lat, l.Lon)
+}
+
+func (g *Geocoder) get(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return fmt.Errorf("error creating geocoding request: %v", err)
+	}
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling geocoding API: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading geocoding response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("geocoding API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("error unmarshalling geocoding response: %v", err)
+	}
+	return nil
+}
+
+// normalize collapses whitespace/case differences so "Los Angeles, US" and
+// "los angeles,  us" share a cache entry.
+func normalize(query string) string {
+	fields := strings.Fields(strings.ToLower(query))
+	return strings.Join(fields, " ")
+}
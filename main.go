@@ -1,36 +1,27 @@
+// Command jupiter is a thin gRPC client for weatherd: it resolves the
+// location and API key on the daemon side and just renders whatever comes
+// back, so the CLI itself never needs an OpenWeatherMap key of its own.
 package main
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
+	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
-	"github.com/spf13/viper"
-)
-
-type WeatherData struct {
-	Name    string        `json:"name"`
-	Main    Main          `json:"main"`
-	Weather []WeatherInfo `json:"weather"`
-}
-
-type Main struct {
-	Temp      float64 `json:"temp"`
-	FeelsLike float64 `json:"feels_like"`
-	Pressure  float64 `json:"pressure"`
-	Humidity  float64 `json:"humidity"`
-}
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
-type WeatherInfo struct {
-	Main        string `json:"main"`
-	Description string `json:"description"`
-}
+	"github.com/ARMeeru/jupiter/output"
+	"github.com/ARMeeru/jupiter/proto/weatherdpb"
+)
 
 func main() {
 	// Initialize logging
@@ -41,98 +32,196 @@ func main() {
 	defer logFile.Close()
 	logger := log.New(logFile, "", log.Ldate|log.Ltime)
 
-	// Check if user has provided command-line argument for location
-	if len(os.Args) < 2 {
-		logger.Println("No location provided")
-		fmt.Println("Please provide a location as a command-line argument.")
+	// Anything other than a known subcommand (including a bare location)
+	// falls through to the original current-conditions behavior so
+	// existing invocations keep working.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "forecast":
+			runForecast(logger, os.Args[2:])
+			return
+		case "batch":
+			runBatch(logger, os.Args[2:])
+			return
+		}
+	}
+	runCurrent(logger, os.Args[1:])
+}
+
+func runCurrent(logger *log.Logger, args []string) {
+	fs := flag.NewFlagSet("current", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:50051", "weatherd address")
+	lat := fs.Float64("lat", 0, "latitude (use with --lon to skip geocoding)")
+	lon := fs.Float64("lon", 0, "longitude (use with --lat to skip geocoding)")
+	format := fs.String("format", "text", "output format: text, json, yaml, table, prometheus")
+	serve := fs.String("serve", "", "serve Prometheus /metrics on this address instead of printing once, e.g. :9090")
+	units := fs.String("units", "", "metric, imperial, or standard (default: weatherd's configured default)")
+	refresh := fs.Bool("refresh", false, "bypass weatherd's cache and revalidate against the network")
+	offline := fs.Bool("offline", false, "serve only from weatherd's cache; fail instead of hitting the network")
+	fs.Parse(args)
+
+	loc, err := buildLocation(*lat, *lon, fs.Args())
+	if err != nil {
+		logger.Println(err)
+		fmt.Println(err)
 		return
 	}
 
-	// Get location from command-line argument and validate input
-	location := strings.TrimSpace(os.Args[1])
-	if location == "" {
-		logger.Println("Invalid input: empty location")
-		fmt.Println("Please provide a valid location.")
+	reqUnits, err := parseUnits(*units)
+	if err != nil {
+		fmt.Println(err)
 		return
 	}
-	if !utf8.ValidString(location) {
-		logger.Println("Invalid input: non-UTF8 character in location")
-		fmt.Println("Please provide a valid location.")
+
+	conn, err := dial(*addr, logger)
+	if err != nil {
 		return
 	}
-	if strings.ContainsAny(location, "!@#$%^&*()_+={}[]|\\;:'\"<>,.?/~`") {
-		logger.Println("Invalid input: invalid character in location")
-		fmt.Println("Please provide a valid location.")
+	defer conn.Close()
+
+	client := weatherdpb.NewWeatherServiceClient(conn)
+
+	if *serve != "" {
+		serveMetrics(*serve, client, loc, reqUnits, logger)
 		return
 	}
 
-	// Read API key from config file
-	viper.SetConfigFile("config.yaml")
-	err = viper.ReadInConfig()
+	renderer, err := output.New(*format)
 	if err != nil {
-		logger.Fatalf("Error reading config file: %v", err)
-	}
-	apiKey := viper.GetString("api_key")
-	if apiKey == "" {
-		logger.Fatalln("API key is missing in the config file")
+		fmt.Println(err)
+		return
 	}
 
-	// Call OpenWeatherMap API to get current conditions for the specified location
-	weatherData, err := getWeatherData(location, apiKey)
+	report, err := fetchCurrentReport(client, loc, reqUnits, *refresh, *offline)
 	if err != nil {
 		logger.Println("Error getting weather data:", err)
-		fmt.Println("Unable to retrieve weather data. Please check your location and try again.")
+		fmt.Println(friendlyError(err))
 		return
 	}
 
-	// Print the weather data to the console
-	fmt.Println("Location: ", weatherData.Name)
-	fmt.Println("Temperature: ", weatherData.Main.Temp, "°C")
-	fmt.Println("Feels like: ", weatherData.Main.FeelsLike, "°C")
-	fmt.Println("Pressure: ", weatherData.Main.Pressure, "hPa")
-	fmt.Println("Humidity: ", weatherData.Main.Humidity, "%")
-	fmt.Println("Weather: ", weatherData.Weather[0].Main)
-	fmt.Println("Description: ", weatherData.Weather[0].Description)
+	if err := renderer.Render(os.Stdout, report); err != nil {
+		logger.Println("Error rendering report:", err)
+		fmt.Println(err)
+	}
 }
 
-func getWeatherData(location string, apiKey string) (*WeatherData, error) {
-	location = strings.ReplaceAll(location, " ", "%20")
-
-	client := &http.Client{}
+func fetchCurrentReport(client weatherdpb.WeatherServiceClient, loc *weatherdpb.Location, units weatherdpb.Units, refresh, offline bool) (output.Report, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.openweathermap.org/data/2.5/weather?q=%s&appid=%s&units=metric", location, apiKey), nil)
+	weatherData, err := client.GetCurrent(ctx, &weatherdpb.RequestCurrent{Location: loc, Units: units, Refresh: refresh, Offline: offline})
 	if err != nil {
-		return nil, fmt.Errorf("error creating HTTP request: %v", err)
+		return output.Report{}, err
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error calling weather API: %v", err)
+	return output.Report{
+		Location: weatherData.Name,
+		Country:  weatherData.Country,
+		Warning:  weatherData.Warning,
+		Current: &output.CurrentData{
+			Temp:        weatherData.Temp,
+			FeelsLike:   weatherData.FeelsLike,
+			Pressure:    weatherData.Pressure,
+			Humidity:    weatherData.Humidity,
+			Condition:   weatherData.Condition,
+			Description: weatherData.Description,
+		},
+	}, nil
+}
+
+// serveMetrics turns the CLI into a Prometheus scrape target: every GET
+// /metrics re-fetches current conditions from weatherd and renders them in
+// exposition format.
+func serveMetrics(addr string, client weatherdpb.WeatherServiceClient, loc *weatherdpb.Location, units weatherdpb.Units, logger *log.Logger) {
+	renderer, _ := output.New("prometheus")
+
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		report, err := fetchCurrentReport(client, loc, units, false, false)
+		if err != nil {
+			logger.Println("Error getting weather data:", err)
+			http.Error(w, friendlyError(err), http.StatusBadGateway)
+			return
+		}
+		if err := renderer.Render(w, report); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	fmt.Printf("Serving Prometheus metrics on %s/metrics\n", addr)
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		logger.Println("Error serving metrics:", err)
+		fmt.Println("Unable to serve metrics:", err)
 	}
-	defer resp.Body.Close()
+}
 
-	// Read response body into a byte slice
-	body, err := ioutil.ReadAll(resp.Body)
+// dial connects to weatherd, logging and printing a friendly message on
+// failure so every subcommand reports connection errors the same way.
+func dial(addr string, logger *log.Logger) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		logger.Println("Error dialing weatherd:", err)
+		fmt.Println("Unable to reach weatherd. Is it running?")
+		return nil, err
+	}
+	return conn, nil
+}
 
-	// Debug with this line if expected data is not found; otherwise comment it out
-	// fmt.Println(string(body))
-	// Found result for "Port Angeles" but not "Los Angeles"
+// buildLocation turns CLI input into a weatherdpb.Location, validating the
+// free-form city argument as the original single-process CLI did, except
+// that commas and periods are allowed ("Los Angeles, US", "90001,US").
+func buildLocation(lat, lon float64, args []string) (*weatherdpb.Location, error) {
+	if lat != 0 || lon != 0 {
+		return &weatherdpb.Location{
+			Query: &weatherdpb.Location_Coordinates{
+				Coordinates: &weatherdpb.Coordinates{Lat: lat, Lon: lon},
+			},
+		}, nil
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
+	if len(args) < 1 {
+		return nil, fmt.Errorf("Please provide a location as a command-line argument, or use --lat/--lon.")
 	}
 
-	// Unmarshal the response JSON into a WeatherData struct
-	var weatherData WeatherData
-	err = json.Unmarshal(body, &weatherData)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %v", err)
+	location := strings.TrimSpace(args[0])
+	if location == "" {
+		return nil, fmt.Errorf("Please provide a valid location.")
+	}
+	if !utf8.ValidString(location) {
+		return nil, fmt.Errorf("Please provide a valid location.")
 	}
+	if strings.ContainsAny(location, "!@#$%^&*()_+={}[]|\\;:'\"<>?/~`") {
+		return nil, fmt.Errorf("Please provide a valid location.")
+	}
+
+	return &weatherdpb.Location{Query: &weatherdpb.Location_City{City: location}}, nil
+}
 
-	// Check if the weather data contains any information
-	if len(weatherData.Weather) == 0 {
-		return nil, errors.New(fmt.Sprintf("no weather data available for the location '%s'", location))
+// parseUnits turns a --units flag value into the proto enum. "" maps to
+// UNITS_UNSPECIFIED, which tells weatherd to apply its own configured
+// default instead of forcing one.
+func parseUnits(s string) (weatherdpb.Units, error) {
+	switch s {
+	case "":
+		return weatherdpb.Units_UNITS_UNSPECIFIED, nil
+	case "metric":
+		return weatherdpb.Units_METRIC, nil
+	case "imperial":
+		return weatherdpb.Units_IMPERIAL, nil
+	case "standard":
+		return weatherdpb.Units_STANDARD, nil
+	default:
+		return weatherdpb.Units_UNITS_UNSPECIFIED, fmt.Errorf("unknown --units %q (want metric, imperial, or standard)", s)
 	}
+}
 
-	return &weatherData, nil
+// friendlyError turns a gRPC status into the same kind of plain-language
+// message the old single-process CLI printed.
+func friendlyError(err error) string {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.NotFound:
+			return "Unable to resolve location. Please check your input and try again."
+		}
+	}
+	return "Unable to retrieve weather data. Please check your location and try again."
 }
@@ -0,0 +1,14 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, r Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,101 @@
+// Package output renders weather reports in whatever shape the caller asked
+// for via --format, instead of the CLI hard-coding a single fmt.Println
+// layout. Adding a format means adding a Renderer, not touching main.go.
+package output
+
+import (
+	"fmt"
+	"io"
+)
+
+// CurrentData is a single current-conditions reading.
+type CurrentData struct {
+	Temp        float64
+	FeelsLike   float64
+	Pressure    float64
+	Humidity    float64
+	Condition   string
+	Description string
+}
+
+// ForecastDay summarizes one calendar day of a forecast.
+type ForecastDay struct {
+	Day       string
+	TempMin   float64
+	TempMax   float64
+	Condition string
+	PrecipMM  float64
+}
+
+// ForecastPeriod is a single 3-hour forecast slot.
+type ForecastPeriod struct {
+	Time        string
+	Temp        float64
+	Condition   string
+	Description string
+	WindSpeed   float64
+	WindDeg     float64
+	WindGust    float64
+	Clouds      float64
+	Visibility  float64
+	PrecipMM    float64
+	Pop         float64
+}
+
+// BatchResult is one location's outcome from a multi-city batch fetch. Err
+// is a string (rather than `error`) so it survives JSON/YAML round-trips.
+type BatchResult struct {
+	Query    string
+	Location string
+	Country  string
+	Current  *CurrentData
+	Warning  string
+	Err      string
+}
+
+// Report is what a Renderer turns into output. Exactly one of Current,
+// Daily, Hourly, or Batch is populated, matching which subcommand produced
+// it.
+type Report struct {
+	Location string
+	Country  string
+
+	// Sunrise and Sunset are unix seconds, populated alongside Daily/Hourly
+	// for forecast reports; current-conditions and batch reports leave
+	// them zero.
+	Sunrise int64
+	Sunset  int64
+
+	// Warning notes any geocoding ambiguity weatherd resolved on the
+	// caller's behalf (see weatherdpb.SendWeather.Warning); "" when there
+	// was nothing to report.
+	Warning string
+
+	Current *CurrentData
+	Daily   []ForecastDay
+	Hourly  []ForecastPeriod
+	Batch   []BatchResult
+}
+
+// Renderer writes a Report to w in some format.
+type Renderer interface {
+	Render(w io.Writer, report Report) error
+}
+
+// New looks up the Renderer registered for format.
+func New(format string) (Renderer, error) {
+	switch format {
+	case "", "text":
+		return textRenderer{}, nil
+	case "json":
+		return jsonRenderer{}, nil
+	case "yaml":
+		return yamlRenderer{}, nil
+	case "table":
+		return tableRenderer{}, nil
+	case "prometheus":
+		return prometheusRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
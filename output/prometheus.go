@@ -0,0 +1,43 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// prometheusRenderer writes a /metrics-style text exposition of current
+// conditions. It only makes sense for a single point-in-time reading, so
+// forecast reports are rejected rather than silently reshaped.
+type prometheusRenderer struct{}
+
+func (prometheusRenderer) Render(w io.Writer, r Report) error {
+	if r.Current == nil && r.Batch == nil {
+		return errors.New("prometheus output format only supports current-conditions or batch reports")
+	}
+
+	fmt.Fprintln(w, "# HELP weather_temp_celsius Current temperature in Celsius.")
+	fmt.Fprintln(w, "# TYPE weather_temp_celsius gauge")
+	fmt.Fprintln(w, "# HELP weather_humidity_ratio Current relative humidity, as a 0-1 ratio.")
+	fmt.Fprintln(w, "# TYPE weather_humidity_ratio gauge")
+	fmt.Fprintln(w, "# HELP weather_pressure_hpa Current barometric pressure in hPa.")
+	fmt.Fprintln(w, "# TYPE weather_pressure_hpa gauge")
+
+	if r.Current != nil {
+		writePrometheusPoint(w, r.Location, r.Country, r.Current)
+	}
+	for _, b := range r.Batch {
+		if b.Current != nil {
+			writePrometheusPoint(w, b.Location, b.Country, b.Current)
+		}
+	}
+
+	return nil
+}
+
+func writePrometheusPoint(w io.Writer, location, country string, c *CurrentData) {
+	labels := fmt.Sprintf(`location="%s",country="%s"`, location, country)
+	fmt.Fprintf(w, "weather_temp_celsius{%s} %f\n", labels, c.Temp)
+	fmt.Fprintf(w, "weather_humidity_ratio{%s} %f\n", labels, c.Humidity/100)
+	fmt.Fprintf(w, "weather_pressure_hpa{%s} %f\n", labels, c.Pressure)
+}
@@ -0,0 +1,107 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// tableRenderer draws a box-drawing ANSI table, one row per data point.
+type tableRenderer struct{}
+
+func (tableRenderer) Render(w io.Writer, r Report) error {
+	switch {
+	case r.Batch != nil:
+		rows := make([][]string, len(r.Batch))
+		for i, b := range r.Batch {
+			if b.Err != "" {
+				rows[i] = []string{b.Query, "-", "-", "error: " + b.Err, "-"}
+				continue
+			}
+			rows[i] = []string{b.Query, fmt.Sprintf("%s, %s", b.Location, b.Country),
+				fmt.Sprintf("%.1f", b.Current.Temp), b.Current.Description, b.Warning}
+		}
+		return drawTable(w, []string{"Query", "Location", "Temp (°C)", "Condition", "Note"}, rows)
+
+	case r.Current != nil:
+		if r.Warning != "" {
+			fmt.Fprintln(w, "Note:", r.Warning)
+		}
+		return drawTable(w,
+			[]string{"Location", "Temp (°C)", "Feels Like (°C)", "Pressure (hPa)", "Humidity (%)", "Condition"},
+			[][]string{{
+				fmt.Sprintf("%s, %s", r.Location, r.Country),
+				fmt.Sprintf("%.1f", r.Current.Temp),
+				fmt.Sprintf("%.1f", r.Current.FeelsLike),
+				fmt.Sprintf("%.0f", r.Current.Pressure),
+				fmt.Sprintf("%.0f", r.Current.Humidity),
+				r.Current.Description,
+			}})
+
+	case r.Hourly != nil:
+		if r.Warning != "" {
+			fmt.Fprintln(w, "Note:", r.Warning)
+		}
+		rows := make([][]string, len(r.Hourly))
+		for i, p := range r.Hourly {
+			rows[i] = []string{p.Time, fmt.Sprintf("%.1f", p.Temp), p.Description,
+				fmt.Sprintf("%.1f@%.0f°", p.WindSpeed, p.WindDeg), fmt.Sprintf("%.0f", p.Clouds),
+				fmt.Sprintf("%.0f", p.Visibility), fmt.Sprintf("%.1f", p.PrecipMM)}
+		}
+		return drawTable(w, []string{"Time", "Temp (°C)", "Condition", "Wind (m/s@deg)", "Clouds (%)", "Visibility (m)", "Precip (mm)"}, rows)
+
+	default:
+		if r.Warning != "" {
+			fmt.Fprintln(w, "Note:", r.Warning)
+		}
+		rows := make([][]string, len(r.Daily))
+		for i, d := range r.Daily {
+			rows[i] = []string{d.Day, fmt.Sprintf("%.1f", d.TempMin), fmt.Sprintf("%.1f", d.TempMax),
+				d.Condition, fmt.Sprintf("%.1f", d.PrecipMM)}
+		}
+		return drawTable(w, []string{"Day", "Min (°C)", "Max (°C)", "Condition", "Precip (mm)"}, rows)
+	}
+}
+
+func drawTable(w io.Writer, header []string, rows [][]string) error {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	printSeparator(w, widths, "┌", "┬", "┐")
+	printRow(w, header, widths)
+	printSeparator(w, widths, "├", "┼", "┤")
+	for _, row := range rows {
+		printRow(w, row, widths)
+	}
+	printSeparator(w, widths, "└", "┴", "┘")
+	return nil
+}
+
+func printSeparator(w io.Writer, widths []int, left, mid, right string) {
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		parts[i] = strings.Repeat("─", width+2)
+	}
+	fmt.Fprintf(w, "%s%s%s\n", left, strings.Join(parts, mid), right)
+}
+
+func printRow(w io.Writer, cells []string, widths []int) {
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		parts[i] = fmt.Sprintf(" %-*s ", width, cell)
+	}
+	fmt.Fprintf(w, "│%s│\n", strings.Join(parts, "│"))
+}
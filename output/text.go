@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// sunriseSunsetLine formats the forecast's city-level sunrise/sunset, or a
+// placeholder when they weren't populated (e.g. an empty report).
+func sunriseSunsetLine(r Report) string {
+	if r.Sunrise == 0 && r.Sunset == 0 {
+		return "Sunrise/sunset: unavailable"
+	}
+	return fmt.Sprintf("Sunrise: %s  Sunset: %s",
+		time.Unix(r.Sunrise, 0).UTC().Format("15:04"), time.Unix(r.Sunset, 0).UTC().Format("15:04"))
+}
+
+// textRenderer reproduces the original plain-English fmt.Println layout.
+type textRenderer struct{}
+
+func (textRenderer) Render(w io.Writer, r Report) error {
+	switch {
+	case r.Batch != nil:
+		for _, b := range r.Batch {
+			if b.Err != "" {
+				fmt.Fprintf(w, "%s: error: %s\n", b.Query, b.Err)
+				continue
+			}
+			fmt.Fprintf(w, "%s: %.1f°C, %s (%s, %s)\n", b.Query, b.Current.Temp, b.Current.Description, b.Location, b.Country)
+			if b.Warning != "" {
+				fmt.Fprintf(w, "  note: %s\n", b.Warning)
+			}
+		}
+
+	case r.Current != nil:
+		if r.Warning != "" {
+			fmt.Fprintln(w, "Note: ", r.Warning)
+		}
+		fmt.Fprintln(w, "Location: ", r.Location)
+		fmt.Fprintln(w, "Temperature: ", r.Current.Temp, "°C")
+		fmt.Fprintln(w, "Feels like: ", r.Current.FeelsLike, "°C")
+		fmt.Fprintln(w, "Pressure: ", r.Current.Pressure, "hPa")
+		fmt.Fprintln(w, "Humidity: ", r.Current.Humidity, "%")
+		fmt.Fprintln(w, "Weather: ", r.Current.Condition)
+		fmt.Fprintln(w, "Description: ", r.Current.Description)
+
+	case r.Hourly != nil:
+		fmt.Fprintf(w, "Forecast for %s, %s\n", r.Location, r.Country)
+		if r.Warning != "" {
+			fmt.Fprintln(w, "Note: ", r.Warning)
+		}
+		fmt.Fprintln(w, sunriseSunsetLine(r))
+		for _, p := range r.Hourly {
+			fmt.Fprintf(w, "%s  %5.1f°C  %-20s  wind %.1fm/s @%.0f° gust %.1fm/s  clouds %.0f%%  vis %.0fm  precip %.1fmm  pop %.0f%%\n",
+				p.Time, p.Temp, p.Description, p.WindSpeed, p.WindDeg, p.WindGust, p.Clouds, p.Visibility, p.PrecipMM, p.Pop*100)
+		}
+
+	default:
+		fmt.Fprintf(w, "Forecast for %s, %s\n", r.Location, r.Country)
+		if r.Warning != "" {
+			fmt.Fprintln(w, "Note: ", r.Warning)
+		}
+		fmt.Fprintln(w, sunriseSunsetLine(r))
+		for _, d := range r.Daily {
+			fmt.Fprintf(w, "%s  %5.1f°C / %5.1f°C  %-20s  precip %.1fmm\n",
+				d.Day, d.TempMin, d.TempMax, d.Condition, d.PrecipMM)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package output
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlRenderer struct{}
+
+func (yamlRenderer) Render(w io.Writer, r Report) error {
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(r)
+}
@@ -0,0 +1,164 @@
+// Package secrets resolves the OpenWeatherMap API key from somewhere other
+// than a plaintext config file: the macOS keychain, the Linux Secret
+// Service, or a pass(1) store, in addition to whatever value was already
+// read from config.yaml or JUPITER_API_KEY. Every backend implements the
+// same Source interface so weatherd doesn't need to care which one
+// actually produced the key.
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// service and account identify the credential entry across all backends.
+const (
+	service = "jupiter"
+	account = "api_key"
+)
+
+// Source is one place an API key might live. Lookup returns ok=false (with
+// a nil error) when the backend simply has nothing to say — not
+// installed, not configured, entry missing — so Resolve can fall through
+// to the next source instead of treating that as fatal.
+type Source interface {
+	Name() string
+	Lookup() (key string, ok bool, err error)
+}
+
+// Plaintext wraps a key that was already resolved some other way (the
+// config file's api_key, or the JUPITER_API_KEY environment variable) so
+// it can be tried through the same Source interface as everything else.
+type Plaintext struct {
+	Key string
+}
+
+func (p Plaintext) Name() string { return "plaintext" }
+
+func (p Plaintext) Lookup() (string, bool, error) {
+	return p.Key, p.Key != "", nil
+}
+
+// Keychain reads the key from the macOS login keychain via the `security`
+// CLI (security find-generic-password).
+type Keychain struct{}
+
+func (Keychain) Name() string { return "keychain" }
+
+func (Keychain) Lookup() (string, bool, error) {
+	if runtime.GOOS != "darwin" {
+		return "", false, nil
+	}
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("error running security: %v", err)
+	}
+	key := strings.TrimSpace(string(out))
+	return key, key != "", nil
+}
+
+// SecretService reads the key from the Linux Secret Service (GNOME
+// Keyring, KWallet, etc.) via the secret-tool CLI, which talks to it over
+// D-Bus. Shelling out avoids pulling a D-Bus binding into this binary for
+// a single lookup.
+type SecretService struct{}
+
+func (SecretService) Name() string { return "secret-service" }
+
+func (SecretService) Lookup() (string, bool, error) {
+	if runtime.GOOS != "linux" {
+		return "", false, nil
+	}
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", false, nil
+	}
+	out, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("error running secret-tool: %v", err)
+	}
+	key := strings.TrimSpace(string(out))
+	return key, key != "", nil
+}
+
+// Pass reads the key from a pass(1) store entry named jupiter/api_key.
+type Pass struct{}
+
+func (Pass) Name() string { return "pass" }
+
+func (Pass) Lookup() (string, bool, error) {
+	if _, err := exec.LookPath("pass"); err != nil {
+		return "", false, nil
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command("pass", "show", service+"/"+account)
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("error running pass: %v", err)
+	}
+	// pass stores the secret on the first line, followed by optional
+	// metadata lines.
+	key := strings.TrimSpace(strings.SplitN(stdout.String(), "\n", 2)[0])
+	return key, key != "", nil
+}
+
+// order is the precedence Resolve walks, after the value already on hand,
+// when the caller hasn't pinned a specific backend: the OS-native secret
+// stores, then pass as the cross-platform fallback.
+var order = []Source{Keychain{}, SecretService{}, Pass{}}
+
+// Resolve returns the API key, trying inline first (the value already
+// read from config.yaml or JUPITER_API_KEY) and then, if that's empty,
+// every secret backend in turn unless backend pins it to one of
+// "keychain", "secret-service", or "pass". It fails loudly, naming every
+// source it tried, when none of them yield a key.
+func Resolve(inline, backend string) (string, error) {
+	if key, ok, _ := (Plaintext{Key: inline}).Lookup(); ok {
+		return key, nil
+	}
+
+	sources := order
+	if backend != "" {
+		src, ok := byName(backend)
+		if !ok {
+			return "", fmt.Errorf("unknown secrets backend %q (want keychain, secret-service, or pass)", backend)
+		}
+		sources = []Source{src}
+	}
+
+	var tried []string
+	for _, src := range sources {
+		tried = append(tried, src.Name())
+		key, ok, err := src.Lookup()
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", src.Name(), err)
+		}
+		if ok {
+			return key, nil
+		}
+	}
+
+	return "", errors.New("no API key found: set api_key in config.yaml, export JUPITER_API_KEY, or store it under service " +
+		fmt.Sprintf("%q account %q", service, account) + " in one of: " + strings.Join(tried, ", "))
+}
+
+func byName(name string) (Source, bool) {
+	for _, src := range order {
+		if src.Name() == name {
+			return src, true
+		}
+	}
+	return nil, false
+}
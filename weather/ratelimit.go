@@ -0,0 +1,74 @@
+package weather
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a minimal rate limiter: it holds up to `burst` tokens,
+// refilling at `rate` tokens/second. Client uses one to stay under
+// OpenWeatherMap's free-tier 60-calls-per-minute cap regardless of how many
+// goroutines (e.g. a batch fetch) are calling through it concurrently.
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	max        float64
+	refillRate float64 // tokens per second
+	last       time.Time
+}
+
+func newTokenBucket(ratePerMinute int, burst int) *tokenBucket {
+	return &tokenBucket{
+		tokens:     float64(burst),
+		max:        float64(burst),
+		refillRate: float64(ratePerMinute) / 60,
+		last:       time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.refillRate
+		if b.tokens > b.max {
+			b.tokens = b.max
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.refillRate * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryBackoff returns the exponential-backoff-with-jitter delay before
+// retry attempt n (0-indexed), capped at maxDelay.
+func retryBackoff(n int, base, maxDelay time.Duration) time.Duration {
+	delay := base * time.Duration(1<<uint(n))
+	if delay > maxDelay || delay <= 0 {
+		delay = maxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) + 1))
+	return delay/2 + jitter/2
+}
+
+// isRetryable reports whether an HTTP status code warrants a retry: rate
+// limiting (429) and upstream server errors (5xx).
+func isRetryable(statusCode int) bool {
+	return statusCode == 429 || statusCode >= 500
+}
@@ -0,0 +1,93 @@
+package weather
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketWaitConsumesBurst(t *testing.T) {
+	b := newTokenBucket(60, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// The initial burst should be available immediately.
+	for i := 0; i < 2; i++ {
+		if err := b.wait(ctx); err != nil {
+			t.Fatalf("wait %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if b.tokens >= 1 {
+		t.Fatalf("expected burst to be exhausted, got %f tokens", b.tokens)
+	}
+}
+
+func TestTokenBucketWaitRespectsContext(t *testing.T) {
+	// 1 token/minute means the bucket won't refill within the deadline below.
+	b := newTokenBucket(1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := b.wait(ctx); err != nil {
+		t.Fatalf("first wait: unexpected error: %v", err)
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel2()
+
+	if err := b.wait(ctx2); err == nil {
+		t.Fatal("expected wait to return an error once the context deadline passed")
+	}
+}
+
+func TestRetryBackoffCapsAtMaxDelay(t *testing.T) {
+	base := 250 * time.Millisecond
+	maxDelay := time.Second
+
+	for n := 0; n < 10; n++ {
+		delay := retryBackoff(n, base, maxDelay)
+		if delay < 0 || delay > maxDelay {
+			t.Fatalf("retryBackoff(%d) = %v, want in [0, %v]", n, delay, maxDelay)
+		}
+	}
+}
+
+func TestRetryBackoffGrowsWithAttempt(t *testing.T) {
+	base := 100 * time.Millisecond
+	maxDelay := 10 * time.Second
+
+	// retryBackoff's floor (delay/2, before jitter) should grow with n, so
+	// attempt 3 never waits less than attempt 0 did in the worst case.
+	prevMin := time.Duration(-1)
+	for n := 0; n < 4; n++ {
+		delay := retryBackoff(n, base, maxDelay)
+		expected := base * time.Duration(1<<uint(n))
+		min := expected / 2
+		if min <= prevMin {
+			t.Fatalf("retryBackoff(%d) floor %v did not grow past previous floor %v", n, min, prevMin)
+		}
+		if delay < min {
+			t.Fatalf("retryBackoff(%d) = %v, want >= %v", n, delay, min)
+		}
+		prevMin = min
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := map[int]bool{
+		200: false,
+		301: false,
+		404: false,
+		429: true,
+		500: true,
+		503: true,
+	}
+	for code, want := range cases {
+		if got := isRetryable(code); got != want {
+			t.Errorf("isRetryable(%d) = %v, want %v", code, got, want)
+		}
+	}
+}
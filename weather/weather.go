@@ -0,0 +1,356 @@
+// Package weather wraps the OpenWeatherMap current-conditions endpoint
+// behind a small Client, so the same lookup logic can be shared by the CLI,
+// the weatherd gRPC daemon, and any future frontend instead of being baked
+// into a single main().
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ARMeeru/jupiter/cache"
+	"github.com/ARMeeru/jupiter/geo"
+)
+
+const (
+	currentURL  = "https://api.openweathermap.org/data/2.5/weather"
+	forecastURL = "https://api.openweathermap.org/data/2.5/forecast"
+
+	// defaultRateLimit matches OpenWeatherMap's free-tier cap.
+	defaultRateLimit = 60 // calls per minute
+	maxRetries       = 4
+	backoffBase      = 250 * time.Millisecond
+	backoffMax       = 8 * time.Second
+
+	// currentTTL and forecastTTL bound how long a cached response is
+	// served before a request is allowed to hit the network again.
+	// Geocoding results use cache.NoExpiry: a place's coordinates don't
+	// change.
+	currentTTL  = 10 * time.Minute
+	forecastTTL = time.Hour
+)
+
+// WeatherData is the current-conditions payload returned by OpenWeatherMap.
+type WeatherData struct {
+	Name    string        `json:"name"`
+	Main    Main          `json:"main"`
+	Weather []WeatherInfo `json:"weather"`
+}
+
+type Main struct {
+	Temp      float64 `json:"temp"`
+	FeelsLike float64 `json:"feels_like"`
+	Pressure  float64 `json:"pressure"`
+	Humidity  float64 `json:"humidity"`
+}
+
+type WeatherInfo struct {
+	Main        string `json:"main"`
+	Description string `json:"description"`
+}
+
+// Forecast is the /data/2.5/forecast payload: a list of 3-hour periods
+// covering the next five days, plus the city-level sunrise/sunset that the
+// endpoint reports once rather than per period.
+type Forecast struct {
+	City City            `json:"city"`
+	List []ForecastEntry `json:"list"`
+}
+
+type City struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Sunrise int64  `json:"sunrise"`
+	Sunset  int64  `json:"sunset"`
+}
+
+// ForecastEntry is a single 3-hour slot.
+type ForecastEntry struct {
+	Dt         int64         `json:"dt"`
+	Main       ForecastMain  `json:"main"`
+	Weather    []WeatherInfo `json:"weather"`
+	Wind       Wind          `json:"wind"`
+	Clouds     Clouds        `json:"clouds"`
+	Rain       Precip        `json:"rain"`
+	Snow       Precip        `json:"snow"`
+	Visibility float64       `json:"visibility"`
+	Pop        float64       `json:"pop"`
+}
+
+type ForecastMain struct {
+	Temp    float64 `json:"temp"`
+	TempMin float64 `json:"temp_min"`
+	TempMax float64 `json:"temp_max"`
+}
+
+type Wind struct {
+	Speed float64 `json:"speed"`
+	Deg   float64 `json:"deg"`
+	Gust  float64 `json:"gust"`
+}
+
+type Clouds struct {
+	All float64 `json:"all"`
+}
+
+// Precip holds the optional rolling rain/snow accumulation OpenWeatherMap
+// reports under the "1h"/"3h" keys; both are normally absent when dry.
+type Precip struct {
+	OneHour   float64 `json:"1h"`
+	ThreeHour float64 `json:"3h"`
+}
+
+// CacheOptions controls how a single call consults the on-disk cache. It's
+// passed per-call rather than stored on Client because one Client is
+// shared across concurrent requests with potentially different
+// --refresh/--offline choices.
+type CacheOptions struct {
+	// Refresh forces the call past the cache and revalidates against the
+	// network, storing the fresh result.
+	Refresh bool
+	// Offline serves only from the cache, failing on a miss instead of
+	// falling back to the network.
+	Offline bool
+}
+
+// Client resolves locations and fetches weather on behalf of the API key it
+// holds. It is the single place that talks to OpenWeatherMap, so that
+// daemons embedding it (see cmd/weatherd) are the only process that needs
+// the key.
+type Client struct {
+	APIKey     string
+	HTTPClient *http.Client
+	Geocoder   *geo.Geocoder
+	Cache      *cache.Cache // nil disables caching entirely
+
+	// DefaultUnits and DefaultLang apply to any Current/FiveDay call that
+	// passes "" for its units/lang argument (i.e. the caller didn't ask
+	// for anything specific). They come from config.Settings, so
+	// JUPITER_UNITS/JUPITER_LANG have somewhere to land.
+	DefaultUnits string
+	DefaultLang  string
+
+	limiter *tokenBucket
+}
+
+// NewClient builds a Client ready for use. The HTTP client reuses
+// connections (keep-alive) and every request is gated by a shared
+// token-bucket limiter so that however many goroutines call through this
+// Client (e.g. a batch fetch), the combined rate stays under
+// OpenWeatherMap's free-tier cap.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		APIKey: apiKey,
+		HTTPClient: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{MaxIdleConns: 16, MaxIdleConnsPerHost: 16, IdleConnTimeout: 90 * time.Second},
+		},
+		Geocoder:     geo.NewGeocoder(apiKey),
+		limiter:      newTokenBucket(defaultRateLimit, defaultRateLimit),
+		DefaultUnits: "metric",
+	}
+}
+
+// resolveQueryOptions fills in "" units/lang from the Client's configured
+// defaults, further defaulting units to "metric" if the Client has none
+// either (e.g. a zero-value Client built outside NewClient).
+func (c *Client) resolveQueryOptions(units, lang string) (string, string) {
+	if units == "" {
+		units = c.DefaultUnits
+	}
+	if units == "" {
+		units = "metric"
+	}
+	if lang == "" {
+		lang = c.DefaultLang
+	}
+	return units, lang
+}
+
+// ResolveLocation turns a free-form query into a canonical geo.Location.
+// Results are cached indefinitely, since a place's coordinates don't
+// change. ctx is passed through to the geocoding API call on a cache miss.
+// The returned string is a note describing any candidates that were passed
+// over to resolve an ambiguous query ("" on a cache hit or an unambiguous
+// match) — see geo.Geocoder.Resolve.
+func (c *Client) ResolveLocation(ctx context.Context, query string, opts CacheOptions) (geo.Location, string, error) {
+	key := "geo|" + strings.ToLower(strings.TrimSpace(query))
+
+	if c.Cache != nil && !opts.Refresh {
+		if body, ok := c.Cache.Get(key, cache.NoExpiry); ok {
+			var loc geo.Location
+			if err := json.Unmarshal(body, &loc); err == nil {
+				return loc, "", nil
+			}
+		}
+	}
+
+	if opts.Offline {
+		return geo.Location{}, "", fmt.Errorf("offline mode: no cached location for %q", query)
+	}
+
+	if err := c.limiter.wait(ctx); err != nil {
+		return geo.Location{}, "", err
+	}
+
+	loc, note, err := c.Geocoder.Resolve(ctx, query)
+	if err != nil {
+		return geo.Location{}, "", err
+	}
+
+	if c.Cache != nil {
+		if body, err := json.Marshal(loc); err == nil {
+			_ = c.Cache.Set(key, body)
+		}
+	}
+
+	return loc, note, nil
+}
+
+// Current fetches current conditions for an already-resolved location.
+// units (e.g. "metric", "imperial", "standard") and lang (an OpenWeatherMap
+// language code) fall back to c.DefaultUnits/c.DefaultLang when "".
+func (c *Client) Current(ctx context.Context, loc geo.Location, units, lang string, opts CacheOptions) (*WeatherData, error) {
+	units, lang = c.resolveQueryOptions(units, lang)
+
+	key := fmt.Sprintf("current|%.4f|%.4f|%s|%s", loc.Lat, loc.Lon, units, lang)
+	url := fmt.Sprintf("%s?lat=%f&lon=%f&appid=%s&units=%s", currentURL, loc.Lat, loc.Lon, c.APIKey, units)
+	if lang != "" {
+		url += "&lang=" + lang
+	}
+
+	body, err := c.fetch(ctx, key, currentTTL, opts, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var weatherData WeatherData
+	if err := json.Unmarshal(body, &weatherData); err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON: %v", err)
+	}
+
+	if len(weatherData.Weather) == 0 {
+		return nil, errors.New(fmt.Sprintf("no weather data available for the location '%s'", loc.Name))
+	}
+
+	if loc.Name != "" {
+		weatherData.Name = loc.Name
+	}
+
+	return &weatherData, nil
+}
+
+// FiveDay fetches the 3-hour-interval, five-day forecast for an
+// already-resolved location. units and lang follow the same fallback rule
+// as Current.
+func (c *Client) FiveDay(ctx context.Context, loc geo.Location, units, lang string, opts CacheOptions) (*Forecast, error) {
+	units, lang = c.resolveQueryOptions(units, lang)
+
+	key := fmt.Sprintf("forecast|%.4f|%.4f|%s|%s", loc.Lat, loc.Lon, units, lang)
+	url := fmt.Sprintf("%s?lat=%f&lon=%f&appid=%s&units=%s", forecastURL, loc.Lat, loc.Lon, c.APIKey, units)
+	if lang != "" {
+		url += "&lang=" + lang
+	}
+
+	body, err := c.fetch(ctx, key, forecastTTL, opts, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var forecast Forecast
+	if err := json.Unmarshal(body, &forecast); err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON: %v", err)
+	}
+
+	if len(forecast.List) == 0 {
+		return nil, errors.New(fmt.Sprintf("no forecast data available for the location '%s'", loc.Name))
+	}
+
+	if loc.Name != "" {
+		forecast.City.Name = loc.Name
+	}
+
+	return &forecast, nil
+}
+
+// fetch returns the raw response body for key, consulting the cache first
+// (unless opts.Refresh), and either erroring on a miss (opts.Offline) or
+// falling through to getRaw and caching what comes back.
+func (c *Client) fetch(ctx context.Context, key string, ttl time.Duration, opts CacheOptions, url string) ([]byte, error) {
+	if c.Cache != nil && !opts.Refresh {
+		if body, ok := c.Cache.Get(key, ttl); ok {
+			return body, nil
+		}
+	}
+
+	if opts.Offline {
+		return nil, fmt.Errorf("offline mode: no cached data for %s", key)
+	}
+
+	body, err := c.getRaw(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Cache != nil {
+		_ = c.Cache.Set(key, body)
+	}
+
+	return body, nil
+}
+
+// getRaw performs a rate-limited GET, retrying with exponential backoff
+// and jitter on 429/5xx responses, and returns the raw response body.
+func (c *Client) getRaw(ctx context.Context, url string) ([]byte, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(retryBackoff(attempt-1, backoffBase, backoffMax)):
+			}
+		}
+
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating HTTP request: %v", err)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("error calling weather API: %v", err)
+			continue
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("error reading response body: %v", err)
+			continue
+		}
+
+		if isRetryable(resp.StatusCode) {
+			lastErr = fmt.Errorf("weather API returned status %d: %s", resp.StatusCode, string(body))
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("weather API returned status %d: %s", resp.StatusCode, string(body))
+		}
+
+		return body, nil
+	}
+
+	return nil, fmt.Errorf("giving up after %d retries: %v", maxRetries, lastErr)
+}